@@ -0,0 +1,83 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	epamcomv1beta1 "github.com/mkosterin/web-operator/api/v1beta1"
+)
+
+// ConvertTo converts this v1alpha1 Web to the Hub version (v1beta1).
+func (src *Web) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*epamcomv1beta1.Web)
+	if !ok {
+		return fmt.Errorf("expected *v1beta1.Web but got %T", dstRaw)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.Content = epamcomv1beta1.ContentSpec{Inline: src.Spec.HtmlContent}
+	dst.Spec.Image = src.Spec.Image
+	dst.Spec.Replicas = src.Spec.Size
+	dst.Spec.Port = src.Spec.ContainerPort
+	if src.Spec.Ingress != nil {
+		dst.Spec.Ingress = &epamcomv1beta1.IngressSpec{
+			Enabled:       src.Spec.Ingress.Enabled,
+			Host:          src.Spec.Ingress.Host,
+			ClassName:     src.Spec.Ingress.ClassName,
+			TLSSecretName: src.Spec.Ingress.TLSSecretName,
+		}
+	}
+
+	dst.Status.Conditions = src.Status.Conditions
+	dst.Status.ObservedGeneration = src.Status.ObservedGeneration
+	dst.Status.URLs = src.Status.URLs
+
+	return nil
+}
+
+// ConvertFrom converts the Hub version (v1beta1) to this v1alpha1 Web.
+func (dst *Web) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*epamcomv1beta1.Web)
+	if !ok {
+		return fmt.Errorf("expected *v1beta1.Web but got %T", srcRaw)
+	}
+
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.HtmlContent = src.Spec.Content.Inline
+	dst.Spec.Image = src.Spec.Image
+	dst.Spec.Size = src.Spec.Replicas
+	dst.Spec.ContainerPort = src.Spec.Port
+	if src.Spec.Ingress != nil {
+		dst.Spec.Ingress = &IngressSpec{
+			Enabled:       src.Spec.Ingress.Enabled,
+			Host:          src.Spec.Ingress.Host,
+			ClassName:     src.Spec.Ingress.ClassName,
+			TLSSecretName: src.Spec.Ingress.TLSSecretName,
+		}
+	}
+
+	dst.Status.Conditions = src.Status.Conditions
+	dst.Status.ObservedGeneration = src.Status.ObservedGeneration
+	dst.Status.URLs = src.Status.URLs
+
+	return nil
+}
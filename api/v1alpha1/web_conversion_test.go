@@ -0,0 +1,75 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	epamcomv1beta1 "github.com/mkosterin/web-operator/api/v1beta1"
+)
+
+func TestConvertToAndFromRoundTrip(t *testing.T) {
+	className := "nginx"
+	src := &Web{
+		ObjectMeta: metav1.ObjectMeta{Name: "roundtrip", Namespace: "default"},
+		Spec: WebSpec{
+			Size:          3,
+			ContainerPort: 8080,
+			Image:         "nginx:1.25",
+			HtmlContent:   "<html>hello</html>",
+			Ingress: &IngressSpec{
+				Enabled:       true,
+				Host:          "example.com",
+				ClassName:     &className,
+				TLSSecretName: "tls-secret",
+			},
+		},
+	}
+
+	var hub epamcomv1beta1.Web
+	if err := src.ConvertTo(&hub); err != nil {
+		t.Fatalf("ConvertTo: %v", err)
+	}
+	if hub.Spec.Replicas != src.Spec.Size {
+		t.Errorf("Replicas = %d, want %d", hub.Spec.Replicas, src.Spec.Size)
+	}
+	if hub.Spec.Port != src.Spec.ContainerPort {
+		t.Errorf("Port = %d, want %d", hub.Spec.Port, src.Spec.ContainerPort)
+	}
+	if hub.Spec.Content.Inline != src.Spec.HtmlContent {
+		t.Errorf("Content.Inline = %q, want %q", hub.Spec.Content.Inline, src.Spec.HtmlContent)
+	}
+	if hub.Spec.Ingress == nil || hub.Spec.Ingress.Host != "example.com" {
+		t.Errorf("Ingress = %+v, want Host=example.com", hub.Spec.Ingress)
+	}
+
+	var back Web
+	if err := back.ConvertFrom(&hub); err != nil {
+		t.Fatalf("ConvertFrom: %v", err)
+	}
+	if back.Spec.Size != src.Spec.Size ||
+		back.Spec.ContainerPort != src.Spec.ContainerPort ||
+		back.Spec.Image != src.Spec.Image ||
+		back.Spec.HtmlContent != src.Spec.HtmlContent {
+		t.Errorf("round-tripped spec = %+v, want %+v", back.Spec, src.Spec)
+	}
+	if back.Spec.Ingress == nil || *back.Spec.Ingress != *src.Spec.Ingress {
+		t.Errorf("round-tripped Ingress = %+v, want %+v", back.Spec.Ingress, src.Spec.Ingress)
+	}
+}
@@ -33,6 +33,37 @@ type WebSpec struct {
 	Size int32 `json:"size,omitempty"`
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	ContainerPort int32 `json:"containerPort,omitempty"`
+
+	// Image is the container image used to serve HtmlContent.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Image string `json:"image,omitempty"`
+
+	// HtmlContent is the raw HTML served from the index page.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	HtmlContent string `json:"htmlContent,omitempty"`
+
+	// Ingress configures external access to the Service fronting this Web.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Ingress *IngressSpec `json:"ingress,omitempty"`
+}
+
+// IngressSpec configures the optional Ingress created for a Web.
+type IngressSpec struct {
+	// Enabled controls whether an Ingress is reconciled for this Web.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Host is the hostname routed to the Web's Service.
+	Host string `json:"host,omitempty"`
+
+	// ClassName is the IngressClass to use. Defaults to the cluster's
+	// default IngressClass when omitted.
+	// +optional
+	ClassName *string `json:"className,omitempty"`
+
+	// TLSSecretName, when set, terminates TLS for Host using this Secret.
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
 }
 
 // WebStatus defines the observed state of Web
@@ -41,6 +72,18 @@ type WebStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 	// +operator-sdk:csv:customresourcedefinitions:type=status
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+
+	// ObservedGeneration is the most recent generation observed for this Web.
+	// It corresponds to the Web's generation, which is updated on mutation by
+	// the API Server, allowing clients to know whether the latest spec has
+	// been acted on.
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// URLs are the resolved addresses at which the Web's content is served,
+	// populated from the Service and, when enabled, the Ingress.
+	// +optional
+	URLs []string `json:"urls,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,130 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/distribution/reference"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// webhooklog is for logging in this package.
+var webhooklog = logf.Log.WithName("web-resource")
+
+// maxHtmlContentBytes caps WebSpec.HtmlContent at 1 MiB, the size limit of
+// the generated ConfigMap.
+const maxHtmlContentBytes = 1 << 20 // 1 MiB
+
+// maxSizeStep bounds how far Spec.Size may move in a single update, so a
+// typo can't suddenly scale a Deployment from 1 to 5 replicas.
+const maxSizeStep = 2
+
+// SetupWebhookWithManager registers the validating webhook for Web.
+func (r *Web) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithValidator(&WebCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-epam-com-v1alpha1-web,mutating=false,failurePolicy=fail,sideEffects=None,groups=epam.com,resources=webs,verbs=create;update,versions=v1alpha1,name=vweb.kb.io,admissionReviewVersions=v1
+
+// WebCustomValidator validates Webs beyond what the kubebuilder markers on
+// WebSpec can express.
+type WebCustomValidator struct{}
+
+var _ webhook.CustomValidator = &WebCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator.
+func (v *WebCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	web, ok := obj.(*Web)
+	if !ok {
+		return nil, fmt.Errorf("expected a Web but got a %T", obj)
+	}
+	webhooklog.Info("validate create", "name", web.Name)
+
+	return nil, validateWebSpec(web).ToAggregate()
+}
+
+// ValidateUpdate implements webhook.CustomValidator.
+func (v *WebCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldWeb, ok := oldObj.(*Web)
+	if !ok {
+		return nil, fmt.Errorf("expected a Web but got a %T", oldObj)
+	}
+	newWeb, ok := newObj.(*Web)
+	if !ok {
+		return nil, fmt.Errorf("expected a Web but got a %T", newObj)
+	}
+	webhooklog.Info("validate update", "name", newWeb.Name)
+
+	allErrs := validateWebSpec(newWeb)
+	allErrs = append(allErrs, validateSizeStep(oldWeb, newWeb)...)
+	return nil, allErrs.ToAggregate()
+}
+
+// ValidateDelete implements webhook.CustomValidator.
+func (v *WebCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateWebSpec validates the fields of web.Spec that the controller
+// actually reads and that have no kubebuilder marker equivalent.
+func validateWebSpec(web *Web) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if web.Spec.Image == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("image"), "image must not be empty"))
+	} else if _, err := reference.ParseAnyReference(web.Spec.Image); err != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("image"), web.Spec.Image, fmt.Sprintf("not a parseable OCI reference: %v", err)))
+	}
+
+	if web.Spec.ContainerPort < 1 || web.Spec.ContainerPort > 65535 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("containerPort"), web.Spec.ContainerPort, "must be between 1 and 65535"))
+	}
+
+	if len(web.Spec.HtmlContent) > maxHtmlContentBytes {
+		allErrs = append(allErrs, field.TooLong(specPath.Child("htmlContent"), "", maxHtmlContentBytes))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs
+}
+
+// validateSizeStep rejects updates that move Spec.Size by more than
+// maxSizeStep replicas in one change.
+func validateSizeStep(oldWeb, newWeb *Web) field.ErrorList {
+	step := newWeb.Spec.Size - oldWeb.Spec.Size
+	if step < 0 {
+		step = -step
+	}
+	if step > maxSizeStep {
+		return field.ErrorList{field.Invalid(field.NewPath("spec", "size"), newWeb.Spec.Size,
+			fmt.Sprintf("size may change by at most %d per update, was %d -> %d", maxSizeStep, oldWeb.Spec.Size, newWeb.Spec.Size))}
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var (
+	webhookTestEnv *envtest.Environment
+	webhookClient  client.Client
+	webhookCtx     = context.Background()
+
+	// webhookEnvtestUnavailable is set by TestMain instead of exiting when
+	// envtest could not be started, so that tests with no envtest
+	// dependency (e.g. web_conversion_test.go) still run.
+	webhookEnvtestUnavailable error
+)
+
+// requireWebhookEnvtest skips t if TestMain was unable to start the envtest
+// control plane. Tests that exercise the webhook against a real API server
+// must call this first.
+func requireWebhookEnvtest(t *testing.T) {
+	t.Helper()
+	if webhookEnvtestUnavailable != nil {
+		t.Skipf("envtest unavailable: %v", webhookEnvtestUnavailable)
+	}
+}
+
+func TestMain(m *testing.M) {
+	admissionv1.AddToScheme(scheme.Scheme)
+
+	webhookOptions := envtest.WebhookInstallOptions{
+		Paths: []string{filepath.Join("..", "..", "config", "webhook")},
+	}
+	webhookTestEnv = &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "config", "crd", "bases")},
+		ErrorIfCRDPathMissing: true,
+		WebhookInstallOptions: webhookOptions,
+	}
+
+	if err := AddToScheme(scheme.Scheme); err != nil {
+		fmt.Fprintln(os.Stderr, "unable to add scheme:", err)
+		os.Exit(1)
+	}
+
+	cfg, err := webhookTestEnv.Start()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "envtest unavailable, skipping tests that need it:", err)
+		webhookEnvtestUnavailable = err
+		os.Exit(m.Run())
+	}
+
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme: scheme.Scheme,
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Host:    webhookOptions.LocalServingHost,
+			Port:    webhookOptions.LocalServingPort,
+			CertDir: webhookOptions.LocalServingCertDir,
+		}),
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "unable to create manager:", err)
+		os.Exit(1)
+	}
+
+	if err := (&Web{}).SetupWebhookWithManager(mgr); err != nil {
+		fmt.Fprintln(os.Stderr, "unable to set up webhook:", err)
+		os.Exit(1)
+	}
+
+	go func() {
+		_ = mgr.Start(webhookCtx)
+	}()
+
+	webhookClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "unable to create client:", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+
+	if err := webhookTestEnv.Stop(); err != nil {
+		fmt.Fprintln(os.Stderr, "unable to stop test environment:", err)
+	}
+
+	os.Exit(code)
+}
+
+func newTestWeb(name string) *Web {
+	return &Web{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: WebSpec{
+			Size:          1,
+			ContainerPort: 8080,
+			Image:         "nginx:1.25",
+			HtmlContent:   "<html>hello</html>",
+		},
+	}
+}
+
+func TestWebhookRejectsInvalidSpecs(t *testing.T) {
+	requireWebhookEnvtest(t)
+
+	tests := []struct {
+		name    string
+		mutate  func(web *Web)
+		wantErr bool
+	}{
+		{name: "valid", mutate: func(web *Web) {}, wantErr: false},
+		{name: "empty image", mutate: func(web *Web) { web.Spec.Image = "" }, wantErr: true},
+		{name: "unparseable image", mutate: func(web *Web) { web.Spec.Image = "!!!not-an-image" }, wantErr: true},
+		{name: "port too low", mutate: func(web *Web) { web.Spec.ContainerPort = 0 }, wantErr: true},
+		{name: "port too high", mutate: func(web *Web) { web.Spec.ContainerPort = 70000 }, wantErr: true},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			web := newTestWeb(fmt.Sprintf("webhook-%d", i))
+			tt.mutate(web)
+
+			err := webhookClient.Create(webhookCtx, web)
+			if tt.wantErr && err == nil {
+				t.Fatalf("expected create to be rejected, got no error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("expected create to succeed, got %v", err)
+			}
+			if err == nil {
+				_ = webhookClient.Delete(webhookCtx, web)
+			}
+		})
+	}
+}
+
+func TestWebhookRejectsLargeSizeStep(t *testing.T) {
+	requireWebhookEnvtest(t)
+
+	web := newTestWeb("webhook-size-step")
+	if err := webhookClient.Create(webhookCtx, web); err != nil {
+		t.Fatalf("create Web: %v", err)
+	}
+	defer func() { _ = webhookClient.Delete(webhookCtx, web) }()
+
+	var fetched Web
+	if err := webhookClient.Get(webhookCtx, types.NamespacedName{Name: web.Name, Namespace: web.Namespace}, &fetched); err != nil {
+		t.Fatalf("get Web: %v", err)
+	}
+
+	fetched.Spec.Size = 5
+	if err := webhookClient.Update(webhookCtx, &fetched); err == nil {
+		t.Fatalf("expected update with oversized Size step to be rejected")
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ContentSpec selects where a Web's HTML comes from. Exactly one of Inline,
+// ConfigMapRef, URL, or Git should be set.
+type ContentSpec struct {
+	// Inline is HTML served as-is.
+	// +optional
+	Inline string `json:"inline,omitempty"`
+
+	// ConfigMapRef points at a ConfigMap whose "index.html" key is served.
+	// +optional
+	ConfigMapRef *corev1.LocalObjectReference `json:"configMapRef,omitempty"`
+
+	// URL fetches content over HTTP(S).
+	// +optional
+	URL *HTTPSource `json:"url,omitempty"`
+
+	// Git fetches content from a Git repository.
+	// +optional
+	Git *GitSource `json:"git,omitempty"`
+}
+
+// HTTPSource fetches HTML content from a plain URL, refreshing it on a
+// schedule and optionally verifying its integrity.
+type HTTPSource struct {
+	// URL is the location content is fetched from.
+	URL string `json:"url"`
+
+	// SHA256, when set, must match the hex-encoded SHA-256 digest of the
+	// fetched body or the fetch is rejected.
+	// +optional
+	SHA256 string `json:"sha256,omitempty"`
+
+	// RefreshInterval controls how often URL is re-fetched. When unset, no
+	// periodic re-fetch is scheduled; URL is still fetched on every reconcile.
+	// +optional
+	RefreshInterval metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+// GitSource fetches HTML content by shallow-cloning a Git repository.
+type GitSource struct {
+	// URL is the repository to clone.
+	URL string `json:"url"`
+
+	// Ref is the branch to check out; tags and commit hashes are not
+	// resolved. Defaults to the repository's default branch.
+	// +optional
+	Ref string `json:"ref,omitempty"`
+
+	// Dir is the subdirectory within the repository whose files are
+	// materialized into the ConfigMap. Defaults to the repository root.
+	// +optional
+	Dir string `json:"dir,omitempty"`
+
+	// Auth, when set, references a Secret of type kubernetes.io/basic-auth
+	// or kubernetes.io/ssh-auth used to authenticate the clone.
+	// +optional
+	Auth *corev1.SecretReference `json:"auth,omitempty"`
+}
+
+// ProbesSpec configures the liveness and readiness probes on the
+// web-container.
+type ProbesSpec struct {
+	// LivenessPath is the HTTP path probed for liveness.
+	// +optional
+	LivenessPath string `json:"livenessPath,omitempty"`
+
+	// ReadinessPath is the HTTP path probed for readiness.
+	// +optional
+	ReadinessPath string `json:"readinessPath,omitempty"`
+}
+
+// IngressSpec configures the optional Ingress created for a Web.
+type IngressSpec struct {
+	// Enabled controls whether an Ingress is reconciled for this Web.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Host is the hostname routed to the Web's Service.
+	Host string `json:"host,omitempty"`
+
+	// ClassName is the IngressClass to use. Defaults to the cluster's
+	// default IngressClass when omitted.
+	// +optional
+	ClassName *string `json:"className,omitempty"`
+
+	// TLSSecretName, when set, terminates TLS for Host using this Secret.
+	// +optional
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+}
+
+// WebSpec defines the desired state of Web
+type WebSpec struct {
+	// Content selects where the served HTML comes from.
+	Content ContentSpec `json:"content,omitempty"`
+
+	// Image is the container image used to serve Content.
+	Image string `json:"image,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=5
+
+	// Replicas is the desired number of Deployment replicas.
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+
+	// Port is the container port the web-container listens on.
+	Port int32 `json:"port,omitempty"`
+
+	// Resources are the compute resources required by the web-container.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Probes configures the web-container's liveness and readiness probes.
+	// +optional
+	Probes *ProbesSpec `json:"probes,omitempty"`
+
+	// Ingress configures external access to the Service fronting this Web.
+	// +optional
+	Ingress *IngressSpec `json:"ingress,omitempty"`
+}
+
+// WebStatus defines the observed state of Web
+type WebStatus struct {
+	// Conditions report the observed state of the Web.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+
+	// ObservedGeneration is the most recent generation observed for this Web.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// URLs are the resolved addresses at which the Web's content is served.
+	// +optional
+	URLs []string `json:"urls,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// Web is the Schema for the webs API
+type Web struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WebSpec   `json:"spec,omitempty"`
+	Status WebStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WebList contains a list of Web
+type WebList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Web `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Web{}, &WebList{})
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/distribution/reference"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// webhooklog is for logging in this package.
+var webhooklog = logf.Log.WithName("web-resource")
+
+// maxInlineContentBytes caps WebSpec.Content.Inline at 1 MiB, the size
+// limit of the generated ConfigMap.
+const maxInlineContentBytes = 1 << 20 // 1 MiB
+
+// SetupWebhookWithManager registers the validating and conversion webhooks
+// for Web. Web implements conversion.Hub, so this also wires up the /convert
+// endpoint serving conversions between the registered API versions.
+func (r *Web) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithValidator(&WebCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-epam-com-v1beta1-web,mutating=false,failurePolicy=fail,sideEffects=None,groups=epam.com,resources=webs,verbs=create;update,versions=v1beta1,name=vweb-v1beta1.kb.io,admissionReviewVersions=v1
+
+// WebCustomValidator validates Webs beyond what the kubebuilder markers on
+// WebSpec can express.
+type WebCustomValidator struct{}
+
+var _ webhook.CustomValidator = &WebCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator.
+func (v *WebCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	web, ok := obj.(*Web)
+	if !ok {
+		return nil, fmt.Errorf("expected a Web but got a %T", obj)
+	}
+	webhooklog.Info("validate create", "name", web.Name)
+
+	return nil, validateWebSpec(web).ToAggregate()
+}
+
+// ValidateUpdate implements webhook.CustomValidator.
+func (v *WebCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	web, ok := newObj.(*Web)
+	if !ok {
+		return nil, fmt.Errorf("expected a Web but got a %T", newObj)
+	}
+	webhooklog.Info("validate update", "name", web.Name)
+
+	return nil, validateWebSpec(web).ToAggregate()
+}
+
+// ValidateDelete implements webhook.CustomValidator.
+func (v *WebCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateWebSpec validates the fields of web.Spec that the controller
+// actually reads and that kubebuilder markers can't fully express.
+func validateWebSpec(web *Web) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	if web.Spec.Image == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("image"), "image must not be empty"))
+	} else if _, err := reference.ParseAnyReference(web.Spec.Image); err != nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("image"), web.Spec.Image, fmt.Sprintf("not a parseable OCI reference: %v", err)))
+	}
+
+	if web.Spec.Port < 1 || web.Spec.Port > 65535 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("port"), web.Spec.Port, "must be between 1 and 65535"))
+	}
+
+	allErrs = append(allErrs, validateContentSpec(web.Spec.Content, specPath.Child("content"))...)
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs
+}
+
+// validateContentSpec rejects a ContentSpec that sets more than one source
+// and bounds Inline at maxInlineContentBytes.
+func validateContentSpec(content ContentSpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	set := 0
+	if content.Inline != "" {
+		set++
+	}
+	if content.ConfigMapRef != nil {
+		set++
+	}
+	if content.URL != nil {
+		set++
+	}
+	if content.Git != nil {
+		set++
+	}
+	if set > 1 {
+		allErrs = append(allErrs, field.Invalid(path, content, "exactly one of inline, configMapRef, url, or git may be set"))
+	}
+
+	if len(content.Inline) > maxInlineContentBytes {
+		allErrs = append(allErrs, field.TooLong(path.Child("inline"), "", maxInlineContentBytes))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	epamcomv1beta1 "github.com/mkosterin/web-operator/api/v1beta1"
+)
+
+// httpCacheEntry is the last successfully fetched body for a Web's
+// HTTPSource, stored with its ETag so resolveURLContent can send
+// If-None-Match and skip re-downloading unchanged content.
+type httpCacheEntry struct {
+	etag string
+	data map[string]string
+}
+
+// resolveContent returns the desired ConfigMap Data for web, the duration
+// after which it should be re-resolved, and whether the returned data is
+// stale (fetched on a previous, since-failed, reconcile rather than this
+// one).
+func (r *WebReconciler) resolveContent(ctx context.Context, web *epamcomv1beta1.Web) (map[string]string, time.Duration, bool, error) {
+	switch {
+	case web.Spec.Content.Git != nil:
+		data, err := r.resolveGitContent(ctx, web)
+		return data, 0, false, err
+	case web.Spec.Content.URL != nil:
+		return r.resolveURLContent(ctx, web)
+	case web.Spec.Content.ConfigMapRef != nil:
+		data, err := r.resolveConfigMapRefContent(ctx, web)
+		return data, 0, false, err
+	default:
+		return map[string]string{"index.html": web.Spec.Content.Inline}, 0, false, nil
+	}
+}
+
+// resolveConfigMapRefContent copies "index.html" out of the referenced
+// ConfigMap so it can be re-served from the Web's own ConfigMap.
+func (r *WebReconciler) resolveConfigMapRefContent(ctx context.Context, web *epamcomv1beta1.Web) (map[string]string, error) {
+	var src corev1.ConfigMap
+	if err := r.Get(ctx, client.ObjectKey{Name: web.Spec.Content.ConfigMapRef.Name, Namespace: web.Namespace}, &src); err != nil {
+		return nil, fmt.Errorf("unable to get referenced ConfigMap: %w", err)
+	}
+	return map[string]string{"index.html": src.Data["index.html"]}, nil
+}
+
+// resolveURLContent fetches web.Spec.Content.URL, reusing the cached body
+// when the server reports it unmodified via ETag, and verifying SHA256 when
+// set. It returns the duration until the next scheduled refresh and whether
+// the returned data is a previously cached, stale result.
+func (r *WebReconciler) resolveURLContent(ctx context.Context, web *epamcomv1beta1.Web) (map[string]string, time.Duration, bool, error) {
+	log := log.FromContext(ctx)
+	src := web.Spec.Content.URL
+	key := types.NamespacedName{Name: web.Name, Namespace: web.Namespace}
+
+	r.httpCacheMu.Lock()
+	cached, hasCached := r.httpCache[key]
+	r.httpCacheMu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src.URL, nil)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("unable to build request for %s: %w", src.URL, err)
+	}
+	if hasCached && cached.etag != "" {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	httpClient := r.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		if hasCached {
+			log.Error(err, "unable to refresh content, serving cached copy", "url", src.URL)
+			return cached.data, src.RefreshInterval.Duration, true, nil
+		}
+		return nil, 0, false, fmt.Errorf("unable to fetch %s: %w", src.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && hasCached {
+		return cached.data, src.RefreshInterval.Duration, false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		if hasCached {
+			log.Error(fmt.Errorf("unexpected status %d", resp.StatusCode), "serving cached copy", "url", src.URL)
+			return cached.data, src.RefreshInterval.Duration, true, nil
+		}
+		return nil, 0, false, fmt.Errorf("unexpected status %d fetching %s", resp.StatusCode, src.URL)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("unable to read body from %s: %w", src.URL, err)
+	}
+
+	if src.SHA256 != "" {
+		sum := sha256.Sum256(body)
+		if hex.EncodeToString(sum[:]) != strings.ToLower(src.SHA256) {
+			return nil, 0, false, fmt.Errorf("content from %s did not match expected sha256", src.URL)
+		}
+	}
+
+	data := map[string]string{"index.html": string(body)}
+
+	r.httpCacheMu.Lock()
+	if r.httpCache == nil {
+		r.httpCache = map[types.NamespacedName]httpCacheEntry{}
+	}
+	r.httpCache[key] = httpCacheEntry{etag: resp.Header.Get("ETag"), data: data}
+	r.httpCacheMu.Unlock()
+
+	return data, src.RefreshInterval.Duration, false, nil
+}
+
+// resolveGitContent shallow-clones web.Spec.Content.Git into an ephemeral
+// directory scoped to this reconcile and materializes the files under Dir
+// into the returned ConfigMap data, keyed by their path relative to Dir with
+// path separators replaced by "_".
+func (r *WebReconciler) resolveGitContent(ctx context.Context, web *epamcomv1beta1.Web) (map[string]string, error) {
+	src := web.Spec.Content.Git
+
+	dir, err := os.MkdirTemp("", "web-operator-git-*")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create clone directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	opts := &git.CloneOptions{
+		URL:   src.URL,
+		Depth: 1,
+	}
+	if src.Ref != "" {
+		opts.ReferenceName = plumbing.NewBranchReferenceName(src.Ref)
+	}
+	if src.Auth != nil {
+		auth, err := r.gitAuthForSecret(ctx, web.Namespace, src.Auth)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve git auth: %w", err)
+		}
+		opts.Auth = auth
+	}
+
+	if _, err := git.PlainCloneContext(ctx, dir, false, opts); err != nil {
+		return nil, fmt.Errorf("unable to clone %s: %w", src.URL, err)
+	}
+
+	root := dir
+	if src.Dir != "" {
+		root = filepath.Join(dir, src.Dir)
+	}
+
+	data := map[string]string{}
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		// ConfigMap Data keys must match [-._a-zA-Z0-9]+, so files nested
+		// under Dir are flattened into a single-level key.
+		key := strings.ReplaceAll(rel, string(filepath.Separator), "_")
+		data[key] = string(contents)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read files from %s: %w", root, err)
+	}
+
+	return data, nil
+}
+
+// gitAuthForSecret resolves a SecretReference into a go-git AuthMethod,
+// supporting the kubernetes.io/basic-auth and kubernetes.io/ssh-auth secret
+// types.
+func (r *WebReconciler) gitAuthForSecret(ctx context.Context, namespace string, ref *corev1.SecretReference) (transport.AuthMethod, error) {
+	ns := ref.Namespace
+	if ns == "" {
+		ns = namespace
+	}
+
+	var secret corev1.Secret
+	if err := r.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: ns}, &secret); err != nil {
+		return nil, fmt.Errorf("unable to get auth Secret: %w", err)
+	}
+
+	switch secret.Type {
+	case corev1.SecretTypeBasicAuth:
+		return &githttp.BasicAuth{
+			Username: string(secret.Data[corev1.BasicAuthUsernameKey]),
+			Password: string(secret.Data[corev1.BasicAuthPasswordKey]),
+		}, nil
+	case corev1.SecretTypeSSHAuth:
+		return gitssh.NewPublicKeys("git", secret.Data[corev1.SSHAuthPrivateKey], "")
+	default:
+		return nil, fmt.Errorf("unsupported Secret type %q for git auth", secret.Type)
+	}
+}
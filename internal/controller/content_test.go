@@ -0,0 +1,212 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	epamcomv1beta1 "github.com/mkosterin/web-operator/api/v1beta1"
+)
+
+func TestResolveURLContentFetchesAndCaches(t *testing.T) {
+	const body = "<html>from url</html>"
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	web := &epamcomv1beta1.Web{
+		ObjectMeta: metav1.ObjectMeta{Name: "url-web", Namespace: "default"},
+		Spec:       epamcomv1beta1.WebSpec{Content: epamcomv1beta1.ContentSpec{URL: &epamcomv1beta1.HTTPSource{URL: srv.URL}}},
+	}
+	r := &WebReconciler{}
+
+	data, _, stale, err := r.resolveURLContent(context.Background(), web)
+	if err != nil {
+		t.Fatalf("resolveURLContent: %v", err)
+	}
+	if stale {
+		t.Error("expected fresh fetch, got stale")
+	}
+	if data["index.html"] != body {
+		t.Errorf("index.html = %q, want %q", data["index.html"], body)
+	}
+
+	if _, _, _, err := r.resolveURLContent(context.Background(), web); err != nil {
+		t.Fatalf("resolveURLContent (cached): %v", err)
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 requests to the server, got %d", requests)
+	}
+}
+
+func TestResolveURLContentRejectsSHA256Mismatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte("unexpected content"))
+	}))
+	defer srv.Close()
+
+	web := &epamcomv1beta1.Web{
+		ObjectMeta: metav1.ObjectMeta{Name: "sha-web", Namespace: "default"},
+		Spec: epamcomv1beta1.WebSpec{Content: epamcomv1beta1.ContentSpec{URL: &epamcomv1beta1.HTTPSource{
+			URL:    srv.URL,
+			SHA256: hex.EncodeToString(sha256.New().Sum(nil)),
+		}}},
+	}
+	r := &WebReconciler{}
+
+	if _, _, _, err := r.resolveURLContent(context.Background(), web); err == nil {
+		t.Fatal("expected a sha256 mismatch error, got nil")
+	}
+}
+
+// newBareRepoFixture creates a bare git repository under t.TempDir containing
+// a single commit with index.html at its root, and returns the repository's
+// path.
+func newBareRepoFixture(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+
+	work := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = work
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	run("init", "-b", "main")
+	if err := os.WriteFile(filepath.Join(work, "index.html"), []byte("<html>from git</html>"), 0o644); err != nil {
+		t.Fatalf("write fixture file: %v", err)
+	}
+	run("add", "index.html")
+	run("commit", "-m", "initial")
+
+	bare := t.TempDir()
+	cmd := exec.Command("git", "clone", "--bare", work, bare)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git clone --bare: %v\n%s", err, out)
+	}
+	return bare
+}
+
+func TestResolveGitContentClonesRepo(t *testing.T) {
+	repo := newBareRepoFixture(t)
+
+	web := &epamcomv1beta1.Web{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-web", Namespace: "default"},
+		Spec:       epamcomv1beta1.WebSpec{Content: epamcomv1beta1.ContentSpec{Git: &epamcomv1beta1.GitSource{URL: repo}}},
+	}
+	r := &WebReconciler{}
+
+	data, err := r.resolveGitContent(context.Background(), web)
+	if err != nil {
+		t.Fatalf("resolveGitContent: %v", err)
+	}
+	if data["index.html"] != "<html>from git</html>" {
+		t.Errorf("index.html = %q, want %q", data["index.html"], "<html>from git</html>")
+	}
+	if len(data) != 1 {
+		t.Errorf("data = %v, want exactly one key (.git internals must not leak into the ConfigMap)", data)
+	}
+}
+
+// newNestedBareRepoFixture creates a bare git repository containing a
+// subdirectory, so resolveGitContent's flattening of nested paths can be
+// exercised.
+func newNestedBareRepoFixture(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+
+	work := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = work
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	run("init", "-b", "main")
+	if err := os.MkdirAll(filepath.Join(work, "assets"), 0o755); err != nil {
+		t.Fatalf("mkdir fixture dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(work, "assets", "style.css"), []byte("body{}"), 0o644); err != nil {
+		t.Fatalf("write fixture file: %v", err)
+	}
+	run("add", "assets/style.css")
+	run("commit", "-m", "initial")
+
+	bare := t.TempDir()
+	cmd := exec.Command("git", "clone", "--bare", work, bare)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git clone --bare: %v\n%s", err, out)
+	}
+	return bare
+}
+
+func TestResolveGitContentFlattensNestedPaths(t *testing.T) {
+	repo := newNestedBareRepoFixture(t)
+
+	web := &epamcomv1beta1.Web{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-nested-web", Namespace: "default"},
+		Spec:       epamcomv1beta1.WebSpec{Content: epamcomv1beta1.ContentSpec{Git: &epamcomv1beta1.GitSource{URL: repo}}},
+	}
+	r := &WebReconciler{}
+
+	data, err := r.resolveGitContent(context.Background(), web)
+	if err != nil {
+		t.Fatalf("resolveGitContent: %v", err)
+	}
+	if data["assets_style.css"] != "body{}" {
+		t.Errorf("data = %v, want flattened key %q", data, "assets_style.css")
+	}
+	for key := range data {
+		if strings.Contains(key, "/") {
+			t.Errorf("ConfigMap key %q contains '/', which the API server will reject", key)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	epamcomv1beta1 "github.com/mkosterin/web-operator/api/v1beta1"
+)
+
+var (
+	testEnv   *envtest.Environment
+	k8sClient client.Client
+	testCtx   = context.Background()
+
+	// envtestUnavailable is set by TestMain instead of exiting when envtest
+	// could not be started, so that tests with no envtest dependency (e.g.
+	// content_test.go) still run.
+	envtestUnavailable error
+)
+
+// requireEnvtest skips t if TestMain was unable to start the envtest
+// control plane. Tests that exercise the reconciler against a real API
+// server must call this first.
+func requireEnvtest(t *testing.T) {
+	t.Helper()
+	if envtestUnavailable != nil {
+		t.Skipf("envtest unavailable: %v", envtestUnavailable)
+	}
+}
+
+func TestMain(m *testing.M) {
+	testEnv = &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "config", "crd", "bases")},
+		ErrorIfCRDPathMissing: true,
+	}
+
+	if err := epamcomv1beta1.AddToScheme(scheme.Scheme); err != nil {
+		fmt.Fprintln(os.Stderr, "unable to add scheme:", err)
+		os.Exit(1)
+	}
+
+	cfg, err := testEnv.Start()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "envtest unavailable, skipping tests that need it:", err)
+		envtestUnavailable = err
+		os.Exit(m.Run())
+	}
+
+	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "unable to create client:", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+
+	if err := testEnv.Stop(); err != nil {
+		fmt.Fprintln(os.Stderr, "unable to stop test environment:", err)
+	}
+
+	os.Exit(code)
+}
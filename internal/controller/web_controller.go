@@ -18,25 +18,72 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"sync"
 
 	errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
-	epamcomv1alpha1 "github.com/mkosterin/web-operator/api/v1alpha1"
+	epamcomv1beta1 "github.com/mkosterin/web-operator/api/v1beta1"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// typeAvailableWeb is the condition type indicating that the Deployment
+	// backing a Web has reconciled and is serving the desired number of
+	// ready replicas.
+	typeAvailableWeb = "Available"
+
+	// typeIngressReadyWeb is the condition type indicating that the Ingress
+	// fronting a Web has been assigned a load balancer address.
+	typeIngressReadyWeb = "IngressReady"
+
+	// typeDegradedWeb is the condition type indicating that a Web is being
+	// torn down.
+	typeDegradedWeb = "Degraded"
+
+	// typeContentFetchedWeb is the condition type indicating whether the
+	// latest reconcile was able to resolve web.Spec.Content.
+	typeContentFetchedWeb = "ContentFetched"
+
+	// typeContentStaleWeb is the condition type indicating that the served
+	// content was fetched on a previous reconcile because the latest fetch
+	// failed.
+	typeContentStaleWeb = "ContentStale"
+
+	// webFinalizer lets the controller run finalizeWeb before a Web and its
+	// owned objects are removed from the API server.
+	webFinalizer = "epam.com/web-finalizer"
 )
 
 // WebReconciler reconciles a Web object
 type WebReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// HTTPClient fetches Spec.Content.URL sources. Defaults to
+	// http.DefaultClient when nil.
+	HTTPClient *http.Client
+
+	httpCacheMu sync.Mutex
+	httpCache   map[types.NamespacedName]httpCacheEntry
 }
 
 // +kubebuilder:rbac:groups=epam.com,resources=webs,verbs=get;list;watch;create;update;patch;delete
@@ -46,20 +93,23 @@ type WebReconciler struct {
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
-// TODO(user): Modify the Reconcile function to compare the state specified by
-// the Web object against the actual cluster state, and then
-// perform operations to make the cluster state reflect the state specified by
-// the user.
+//
+// It ensures the ConfigMap and Deployment backing a Web exist, reconciles
+// any drift between web.Spec and the live objects, and reports convergence
+// through WebStatus.Conditions.
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.18.4/pkg/reconcile
 func (r *WebReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 
-	var web epamcomv1alpha1.Web
+	var web epamcomv1beta1.Web
 	if err := r.Get(ctx, req.NamespacedName, &web); err != nil {
 		if errors.IsNotFound(err) {
 			log.Info("Web resource not found. Ignoring since object must be deleted")
@@ -69,60 +119,443 @@ func (r *WebReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	if web.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(&web, webFinalizer) {
+			controllerutil.AddFinalizer(&web, webFinalizer)
+			if err := r.Update(ctx, &web); err != nil {
+				log.Error(err, "unable to add finalizer")
+				return ctrl.Result{}, err
+			}
+		}
+	} else {
+		if controllerutil.ContainsFinalizer(&web, webFinalizer) {
+			return r.finalizeWeb(ctx, &web)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if len(web.Status.Conditions) == 0 {
+		meta.SetStatusCondition(&web.Status.Conditions, metav1.Condition{
+			Type:    typeAvailableWeb,
+			Status:  metav1.ConditionUnknown,
+			Reason:  "Reconciling",
+			Message: "Starting reconciliation",
+		})
+		if err := r.Status().Update(ctx, &web); err != nil {
+			log.Error(err, "unable to update Web status")
+			return ctrl.Result{}, err
+		}
+		if err := r.Get(ctx, req.NamespacedName, &web); err != nil {
+			log.Error(err, "unable to re-fetch Web")
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+	}
+
+	data, refreshAfter, stale, err := r.resolveContent(ctx, &web)
+	if err != nil {
+		meta.SetStatusCondition(&web.Status.Conditions, metav1.Condition{
+			Type:    typeContentFetchedWeb,
+			Status:  metav1.ConditionFalse,
+			Reason:  "FetchFailed",
+			Message: err.Error(),
+		})
+		return r.markUnavailable(ctx, &web, "ContentReconcileFailed", err)
+	}
+	meta.SetStatusCondition(&web.Status.Conditions, metav1.Condition{
+		Type:    typeContentFetchedWeb,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Fetched",
+		Message: "Content resolved successfully",
+	})
+	if stale {
+		meta.SetStatusCondition(&web.Status.Conditions, metav1.Condition{
+			Type:    typeContentStaleWeb,
+			Status:  metav1.ConditionTrue,
+			Reason:  "UsingCachedContent",
+			Message: "Serving previously fetched content after a failed refresh",
+		})
+	} else {
+		meta.SetStatusCondition(&web.Status.Conditions, metav1.Condition{
+			Type:    typeContentStaleWeb,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Fresh",
+			Message: "Content reflects the latest fetch",
+		})
+	}
+
+	cm, changed, err := r.ensureConfigMap(ctx, &web, data)
+	if err != nil {
+		return r.markUnavailable(ctx, &web, "ConfigMapReconcileFailed", err)
+	}
+	if changed {
+		log.Info("ConfigMap reconciled, requeueing", "configMap", cm.Name)
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	dep, changed, err := r.ensureDeployment(ctx, &web, cm)
+	if err != nil {
+		return r.markUnavailable(ctx, &web, "DeploymentReconcileFailed", err)
+	}
+	if changed {
+		log.Info("Deployment reconciled, requeueing", "deployment", dep.Name)
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if dep.Status.ReadyReplicas != web.Spec.Replicas {
+		return r.markUnavailable(ctx, &web, "DeploymentNotReady", fmt.Errorf(
+			"%d/%d replicas ready", dep.Status.ReadyReplicas, web.Spec.Replicas))
+	}
+
+	svc, changed, err := r.ensureService(ctx, &web)
+	if err != nil {
+		return r.markUnavailable(ctx, &web, "ServiceReconcileFailed", err)
+	}
+	if changed {
+		log.Info("Service reconciled, requeueing", "service", svc.Name)
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	urls := []string{fmt.Sprintf("http://%s.%s.svc.cluster.local", svc.Name, svc.Namespace)}
+
+	if web.Spec.Ingress != nil && web.Spec.Ingress.Enabled {
+		ing, changed, err := r.ensureIngress(ctx, &web, svc)
+		if err != nil {
+			return r.markUnavailable(ctx, &web, "IngressReconcileFailed", err)
+		}
+		if changed {
+			log.Info("Ingress reconciled, requeueing", "ingress", ing.Name)
+			return ctrl.Result{Requeue: true}, nil
+		}
+
+		if len(ing.Status.LoadBalancer.Ingress) == 0 {
+			meta.SetStatusCondition(&web.Status.Conditions, metav1.Condition{
+				Type:    typeIngressReadyWeb,
+				Status:  metav1.ConditionFalse,
+				Reason:  "LoadBalancerPending",
+				Message: fmt.Sprintf("Ingress %s has no load balancer address yet", ing.Name),
+			})
+		} else {
+			meta.SetStatusCondition(&web.Status.Conditions, metav1.Condition{
+				Type:    typeIngressReadyWeb,
+				Status:  metav1.ConditionTrue,
+				Reason:  "LoadBalancerReady",
+				Message: fmt.Sprintf("Ingress %s has a load balancer address", ing.Name),
+			})
+			scheme := "http"
+			if web.Spec.Ingress.TLSSecretName != "" {
+				scheme = "https"
+			}
+			urls = append(urls, fmt.Sprintf("%s://%s", scheme, web.Spec.Ingress.Host))
+		}
+	}
+
+	meta.SetStatusCondition(&web.Status.Conditions, metav1.Condition{
+		Type:    typeAvailableWeb,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Reconciled",
+		Message: fmt.Sprintf("Deployment %s has %d ready replicas", dep.Name, dep.Status.ReadyReplicas),
+	})
+	web.Status.ObservedGeneration = web.Generation
+	web.Status.URLs = urls
+	if err := r.Status().Update(ctx, &web); err != nil {
+		log.Error(err, "unable to update Web status")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: refreshAfter}, nil
+}
+
+// markUnavailable records a non-terminal Available=False condition on web
+// and returns the error so the controller-runtime work queue retries with
+// backoff.
+func (r *WebReconciler) markUnavailable(ctx context.Context, web *epamcomv1beta1.Web, reason string, cause error) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	meta.SetStatusCondition(&web.Status.Conditions, metav1.Condition{
+		Type:    typeAvailableWeb,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: cause.Error(),
+	})
+	web.Status.ObservedGeneration = web.Generation
+	if err := r.Status().Update(ctx, web); err != nil {
+		log.Error(err, "unable to update Web status")
+	}
+	log.Error(cause, "reconciliation did not converge", "reason", reason)
+	return ctrl.Result{}, cause
+}
+
+// finalizeWeb drains the Deployment backing web before it and its owned
+// objects are removed from the API server. It scales the Deployment to zero,
+// requeues until no replicas remain ready, records a terminal Degraded
+// condition, and emits an event, then removes webFinalizer so deletion can
+// proceed.
+func (r *WebReconciler) finalizeWeb(ctx context.Context, web *epamcomv1beta1.Web) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	dep := &appsv1.Deployment{}
+	err := r.Get(ctx, client.ObjectKey{Name: web.Name + "deployment", Namespace: web.Namespace}, dep)
+	if err != nil && !errors.IsNotFound(err) {
+		log.Error(err, "unable to get Deployment during finalization")
+		return ctrl.Result{}, err
+	}
+
+	if err == nil {
+		if dep.Spec.Replicas == nil || *dep.Spec.Replicas != 0 {
+			var zero int32
+			dep.Spec.Replicas = &zero
+			if err := r.Update(ctx, dep); err != nil {
+				log.Error(err, "unable to scale down Deployment during finalization")
+				return ctrl.Result{}, err
+			}
+			log.Info("Deployment scaled to zero for finalization", "deployment", dep.Name)
+			return ctrl.Result{Requeue: true}, nil
+		}
+		if dep.Status.ReadyReplicas != 0 {
+			log.Info("waiting for Deployment to drain", "deployment", dep.Name, "readyReplicas", dep.Status.ReadyReplicas)
+			return ctrl.Result{Requeue: true}, nil
+		}
+	}
+
+	meta.SetStatusCondition(&web.Status.Conditions, metav1.Condition{
+		Type:    typeDegradedWeb,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Finalizing",
+		Message: "Web is being torn down",
+	})
+	if err := r.Status().Update(ctx, web); err != nil {
+		log.Error(err, "unable to update Web status during finalization")
+		return ctrl.Result{}, err
+	}
+
+	if r.Recorder != nil {
+		r.Recorder.Event(web, corev1.EventTypeNormal, "Finalizing", "Web has drained its Deployment and is being removed")
+	}
+
+	controllerutil.RemoveFinalizer(web, webFinalizer)
+	if err := r.Update(ctx, web); err != nil {
+		log.Error(err, "unable to remove finalizer")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// ensureConfigMap creates the ConfigMap backing web if it does not exist, or
+// patches its contents when the data resolved by resolveContent has drifted
+// from the live object. The second return value reports whether the
+// ConfigMap was created or updated.
+func (r *WebReconciler) ensureConfigMap(ctx context.Context, web *epamcomv1beta1.Web, data map[string]string) (*corev1.ConfigMap, bool, error) {
+	log := log.FromContext(ctx)
+
 	cm := &corev1.ConfigMap{}
-	err_cm := r.Get(ctx, client.ObjectKey{Name: web.Name + "-cm", Namespace: web.Namespace}, cm)
-	if err_cm != nil && errors.IsNotFound(err_cm) {
+	err := r.Get(ctx, client.ObjectKey{Name: web.Name + "-cm", Namespace: web.Namespace}, cm)
+	if errors.IsNotFound(err) {
 		cm = &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      web.Name + "-cm",
 				Namespace: web.Namespace,
 			},
-			Data: map[string]string{
-				"index.html": web.Spec.HtmlContent,
-			},
+			Data: data,
 		}
-		if err := ctrl.SetControllerReference(&web, cm, r.Scheme); err != nil {
-			log.Error(err, "unable to set owner reference on ConfigMap")
-			return ctrl.Result{}, err
+		if err := ctrl.SetControllerReference(web, cm, r.Scheme); err != nil {
+			return nil, false, fmt.Errorf("unable to set owner reference on ConfigMap: %w", err)
 		}
 		if err := r.Create(ctx, cm); err != nil && !errors.IsAlreadyExists(err) {
-			log.Error(err, "unable to create ConfigMap for Web", "configMap", cm)
-			return ctrl.Result{}, err
+			return nil, false, fmt.Errorf("unable to create ConfigMap: %w", err)
 		}
 		log.Info("ConfigMap has been created", "configMap", cm.Name)
+		return cm, true, nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("unable to get ConfigMap: %w", err)
+	}
 
-	} else if err_cm != nil {
-		log.Error(err_cm, "unable to get ConfigMap")
-		return ctrl.Result{}, err_cm
+	if !maps.Equal(cm.Data, data) {
+		cm.Data = data
+		if err := r.Update(ctx, cm); err != nil {
+			return nil, false, fmt.Errorf("unable to update ConfigMap: %w", err)
+		}
+		log.Info("ConfigMap content drift corrected", "configMap", cm.Name)
+		return cm, true, nil
 	}
 
+	return cm, false, nil
+}
+
+// ensureDeployment creates the Deployment backing web if it does not exist,
+// or updates it when Spec.Replicas, Spec.Image, Spec.Port, Spec.Resources, or
+// Spec.Probes have drifted from the live object. The second return value
+// reports whether the Deployment was created or updated.
+func (r *WebReconciler) ensureDeployment(ctx context.Context, web *epamcomv1beta1.Web, cm *corev1.ConfigMap) (*appsv1.Deployment, bool, error) {
+	log := log.FromContext(ctx)
+
 	dep := &appsv1.Deployment{}
-	err_dep := r.Get(ctx, client.ObjectKey{Name: web.Name + "deployment", Namespace: web.Namespace}, dep)
-	if err_dep != nil && errors.IsNotFound(err_dep) {
-		dep := &appsv1.Deployment{
+	err := r.Get(ctx, client.ObjectKey{Name: web.Name + "deployment", Namespace: web.Namespace}, dep)
+	if errors.IsNotFound(err) {
+		dep = r.deploymentForWeb(web, cm)
+		if err := ctrl.SetControllerReference(web, dep, r.Scheme); err != nil {
+			return nil, false, fmt.Errorf("unable to set owner reference on Deployment: %w", err)
+		}
+		if err := r.Create(ctx, dep); err != nil && !errors.IsAlreadyExists(err) {
+			return nil, false, fmt.Errorf("unable to create Deployment: %w", err)
+		}
+		log.Info("Deployment has been created", "deployment", dep.Name)
+		return dep, true, nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("unable to get Deployment: %w", err)
+	}
+
+	drifted := false
+	if dep.Spec.Replicas == nil || *dep.Spec.Replicas != web.Spec.Replicas {
+		dep.Spec.Replicas = &web.Spec.Replicas
+		drifted = true
+	}
+	if len(dep.Spec.Template.Spec.Containers) > 0 {
+		container := &dep.Spec.Template.Spec.Containers[0]
+		if container.Image != web.Spec.Image {
+			container.Image = web.Spec.Image
+			drifted = true
+		}
+		if len(container.Ports) == 0 || container.Ports[0].ContainerPort != web.Spec.Port {
+			container.Ports = []corev1.ContainerPort{{ContainerPort: web.Spec.Port}}
+			drifted = true
+		}
+		if !reflect.DeepEqual(container.Resources, web.Spec.Resources) {
+			container.Resources = web.Spec.Resources
+			drifted = true
+		}
+		wantLiveness, wantReadiness := probesForWeb(web)
+		if !reflect.DeepEqual(container.LivenessProbe, wantLiveness) {
+			container.LivenessProbe = wantLiveness
+			drifted = true
+		}
+		if !reflect.DeepEqual(container.ReadinessProbe, wantReadiness) {
+			container.ReadinessProbe = wantReadiness
+			drifted = true
+		}
+	}
+
+	if drifted {
+		if err := r.Update(ctx, dep); err != nil {
+			return nil, false, fmt.Errorf("unable to update Deployment: %w", err)
+		}
+		log.Info("Deployment drift corrected", "deployment", dep.Name)
+		return dep, true, nil
+	}
+
+	return dep, false, nil
+}
+
+// ensureService creates the Service fronting web's Deployment if it does not
+// exist, or updates its port when web.Spec.Port has drifted. The
+// second return value reports whether the Service was created or updated.
+func (r *WebReconciler) ensureService(ctx context.Context, web *epamcomv1beta1.Web) (*corev1.Service, bool, error) {
+	log := log.FromContext(ctx)
+
+	svc := &corev1.Service{}
+	err := r.Get(ctx, client.ObjectKey{Name: web.Name + "-svc", Namespace: web.Namespace}, svc)
+	if errors.IsNotFound(err) {
+		svc = &corev1.Service{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      web.Name + "deployment",
+				Name:      web.Name + "-svc",
 				Namespace: web.Namespace,
 			},
-			Spec: appsv1.DeploymentSpec{
-				Selector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{"app": web.Name},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{"app": web.Name},
+				Ports: []corev1.ServicePort{
+					{Port: web.Spec.Port, TargetPort: intstr.FromInt32(web.Spec.Port)},
 				},
-				Template: corev1.PodTemplateSpec{
-					ObjectMeta: metav1.ObjectMeta{
-						Labels: map[string]string{
-							"app": web.Name,
-						},
-					},
-					Spec: corev1.PodSpec{
-						Containers: []corev1.Container{
-							{
-								Image: web.Spec.Image,
-								Name:  "web-container",
-								VolumeMounts: []corev1.VolumeMount{
-									{
-										MountPath: "/app",
-										Name:      "html",
+			},
+		}
+		if err := ctrl.SetControllerReference(web, svc, r.Scheme); err != nil {
+			return nil, false, fmt.Errorf("unable to set owner reference on Service: %w", err)
+		}
+		if err := r.Create(ctx, svc); err != nil && !errors.IsAlreadyExists(err) {
+			return nil, false, fmt.Errorf("unable to create Service: %w", err)
+		}
+		log.Info("Service has been created", "service", svc.Name)
+		return svc, true, nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("unable to get Service: %w", err)
+	}
+
+	if len(svc.Spec.Ports) == 0 || svc.Spec.Ports[0].Port != web.Spec.Port {
+		svc.Spec.Ports = []corev1.ServicePort{
+			{Port: web.Spec.Port, TargetPort: intstr.FromInt32(web.Spec.Port)},
+		}
+		if err := r.Update(ctx, svc); err != nil {
+			return nil, false, fmt.Errorf("unable to update Service: %w", err)
+		}
+		log.Info("Service port drift corrected", "service", svc.Name)
+		return svc, true, nil
+	}
+
+	return svc, false, nil
+}
+
+// ensureIngress creates the Ingress routing web.Spec.Ingress.Host to svc if
+// it does not exist, or updates it when the host, class, or TLS secret have
+// drifted. The second return value reports whether the Ingress was created
+// or updated.
+func (r *WebReconciler) ensureIngress(ctx context.Context, web *epamcomv1beta1.Web, svc *corev1.Service) (*networkingv1.Ingress, bool, error) {
+	log := log.FromContext(ctx)
+
+	ing := &networkingv1.Ingress{}
+	err := r.Get(ctx, client.ObjectKey{Name: web.Name + "-ingress", Namespace: web.Namespace}, ing)
+	if errors.IsNotFound(err) {
+		ing = r.ingressForWeb(web, svc)
+		if err := ctrl.SetControllerReference(web, ing, r.Scheme); err != nil {
+			return nil, false, fmt.Errorf("unable to set owner reference on Ingress: %w", err)
+		}
+		if err := r.Create(ctx, ing); err != nil && !errors.IsAlreadyExists(err) {
+			return nil, false, fmt.Errorf("unable to create Ingress: %w", err)
+		}
+		log.Info("Ingress has been created", "ingress", ing.Name)
+		return ing, true, nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("unable to get Ingress: %w", err)
+	}
+
+	desired := r.ingressForWeb(web, svc)
+	if !ingressSpecsEqual(ing.Spec, desired.Spec) {
+		ing.Spec = desired.Spec
+		if err := r.Update(ctx, ing); err != nil {
+			return nil, false, fmt.Errorf("unable to update Ingress: %w", err)
+		}
+		log.Info("Ingress drift corrected", "ingress", ing.Name)
+		return ing, true, nil
+	}
+
+	return ing, false, nil
+}
+
+// ingressForWeb returns the desired Ingress for web, routing
+// web.Spec.Ingress.Host to svc.
+func (r *WebReconciler) ingressForWeb(web *epamcomv1beta1.Web, svc *corev1.Service) *networkingv1.Ingress {
+	pathType := networkingv1.PathTypePrefix
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      web.Name + "-ingress",
+			Namespace: web.Namespace,
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: web.Spec.Ingress.ClassName,
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: web.Spec.Ingress.Host,
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path:     "/",
+									PathType: &pathType,
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: svc.Name,
+											Port: networkingv1.ServiceBackendPort{
+												Number: web.Spec.Port,
+											},
+										},
 									},
 								},
 							},
@@ -130,41 +563,145 @@ func (r *WebReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 					},
 				},
 			},
+		},
+	}
+	if web.Spec.Ingress.TLSSecretName != "" {
+		ing.Spec.TLS = []networkingv1.IngressTLS{
+			{Hosts: []string{web.Spec.Ingress.Host}, SecretName: web.Spec.Ingress.TLSSecretName},
 		}
-		dep.Spec.Template.Spec.Volumes = []corev1.Volume{
-			{
-				Name: "html",
-				VolumeSource: corev1.VolumeSource{
-					ConfigMap: &corev1.ConfigMapVolumeSource{
-						LocalObjectReference: corev1.LocalObjectReference{
-							Name: cm.Name,
+	}
+	return ing
+}
+
+// ingressSpecsEqual reports whether two IngressSpecs describe the same
+// host, backend, class, and TLS configuration.
+func ingressSpecsEqual(a, b networkingv1.IngressSpec) bool {
+	if len(a.Rules) != 1 || len(b.Rules) != 1 {
+		return false
+	}
+	if a.Rules[0].Host != b.Rules[0].Host {
+		return false
+	}
+	if (a.IngressClassName == nil) != (b.IngressClassName == nil) {
+		return false
+	}
+	if a.IngressClassName != nil && *a.IngressClassName != *b.IngressClassName {
+		return false
+	}
+	aBackend := a.Rules[0].HTTP.Paths[0].Backend.Service
+	bBackend := b.Rules[0].HTTP.Paths[0].Backend.Service
+	if aBackend.Name != bBackend.Name || aBackend.Port.Number != bBackend.Port.Number {
+		return false
+	}
+	aTLS, bTLS := len(a.TLS) > 0, len(b.TLS) > 0
+	if aTLS != bTLS {
+		return false
+	}
+	if aTLS && a.TLS[0].SecretName != b.TLS[0].SecretName {
+		return false
+	}
+	return true
+}
+
+// probesForWeb returns the liveness and readiness probes to configure on
+// web-container, derived from web.Spec.Probes. Either return value is nil
+// when the corresponding path is unset.
+func probesForWeb(web *epamcomv1beta1.Web) (liveness, readiness *corev1.Probe) {
+	if web.Spec.Probes == nil {
+		return nil, nil
+	}
+	if web.Spec.Probes.LivenessPath != "" {
+		liveness = probeForPath(web.Spec.Probes.LivenessPath, web.Spec.Port)
+	}
+	if web.Spec.Probes.ReadinessPath != "" {
+		readiness = probeForPath(web.Spec.Probes.ReadinessPath, web.Spec.Port)
+	}
+	return liveness, readiness
+}
+
+// probeForPath returns an HTTP GET probe against path on port. The fields
+// the API server defaults are set explicitly so the probe compares equal to
+// the one read back from a live Deployment.
+func probeForPath(path string, port int32) *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path:   path,
+				Port:   intstr.FromInt32(port),
+				Scheme: corev1.URISchemeHTTP,
+			},
+		},
+		TimeoutSeconds:   1,
+		PeriodSeconds:    10,
+		SuccessThreshold: 1,
+		FailureThreshold: 3,
+	}
+}
+
+// deploymentForWeb returns the desired Deployment for web, serving cm's
+// index.html on web.Spec.Port.
+func (r *WebReconciler) deploymentForWeb(web *epamcomv1beta1.Web, cm *corev1.ConfigMap) *appsv1.Deployment {
+	liveness, readiness := probesForWeb(web)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      web.Name + "deployment",
+			Namespace: web.Namespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &web.Spec.Replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": web.Name},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"app": web.Name,
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Image: web.Spec.Image,
+							Name:  "web-container",
+							Ports: []corev1.ContainerPort{
+								{ContainerPort: web.Spec.Port},
+							},
+							Resources:      web.Spec.Resources,
+							LivenessProbe:  liveness,
+							ReadinessProbe: readiness,
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									MountPath: "/app",
+									Name:      "html",
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "html",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: cm.Name,
+									},
+								},
+							},
 						},
 					},
 				},
 			},
-		}
-		if err := ctrl.SetControllerReference(&web, dep, r.Scheme); err != nil {
-			log.Error(err, "unable to set owner reference on Deployment")
-			return ctrl.Result{}, err
-		}
-		if err := r.Create(ctx, dep); err != nil && !errors.IsAlreadyExists(err) {
-			log.Error(err, "unable to create Deployment for Web", "deployment", dep)
-			return ctrl.Result{}, err
-		}
-		log.Info("Deployment has been created", "deployemnt", dep.Name)
-	} else if err_dep != nil {
-		log.Error(err_dep, "unable to get Deployment")
-		return ctrl.Result{}, err_dep
+		},
 	}
-
-	return ctrl.Result{}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *WebReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&epamcomv1alpha1.Web{}).
+		For(&epamcomv1beta1.Web{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.ConfigMap{}).
+		Owns(&corev1.Service{}).
+		Owns(&networkingv1.Ingress{}).
 		Complete(r)
 }
@@ -0,0 +1,295 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	epamcomv1beta1 "github.com/mkosterin/web-operator/api/v1beta1"
+)
+
+// reconcileUntilStable drives the reconciler for name until it stops
+// requesting a requeue, marking the Deployment ready along the way to
+// unblock the Available condition.
+func reconcileUntilStable(t *testing.T, r *WebReconciler, name types.NamespacedName) {
+	t.Helper()
+
+	for i := 0; i < 5; i++ {
+		var dep appsv1.Deployment
+		if err := k8sClient.Get(testCtx, types.NamespacedName{Name: name.Name + "deployment", Namespace: name.Namespace}, &dep); err == nil {
+			dep.Status.ReadyReplicas = *dep.Spec.Replicas
+			_ = k8sClient.Status().Update(testCtx, &dep)
+		}
+
+		res, err := r.Reconcile(testCtx, ctrl.Request{NamespacedName: name})
+		if err != nil {
+			t.Fatalf("reconcile %d: %v", i, err)
+		}
+		if !res.Requeue && res.RequeueAfter == 0 {
+			return
+		}
+	}
+	t.Fatalf("reconciliation for %s did not stabilize", name)
+}
+
+func TestReconcileDriftDetection(t *testing.T) {
+	requireEnvtest(t)
+
+	r := &WebReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+	tests := []struct {
+		name    string
+		mutate  func(web *epamcomv1beta1.Web)
+		wantCM  func(cm *corev1.ConfigMap) bool
+		wantDep func(dep *appsv1.Deployment) bool
+	}{
+		{
+			name: "image change",
+			mutate: func(web *epamcomv1beta1.Web) {
+				web.Spec.Image = "nginx:1.27"
+			},
+			wantDep: func(dep *appsv1.Deployment) bool {
+				return dep.Spec.Template.Spec.Containers[0].Image == "nginx:1.27"
+			},
+		},
+		{
+			name: "size change",
+			mutate: func(web *epamcomv1beta1.Web) {
+				web.Spec.Replicas = 3
+			},
+			wantDep: func(dep *appsv1.Deployment) bool {
+				return dep.Spec.Replicas != nil && *dep.Spec.Replicas == 3
+			},
+		},
+		{
+			name: "html content change",
+			mutate: func(web *epamcomv1beta1.Web) {
+				web.Spec.Content.Inline = "<html>updated</html>"
+			},
+			wantCM: func(cm *corev1.ConfigMap) bool {
+				return cm.Data["index.html"] == "<html>updated</html>"
+			},
+		},
+		{
+			name: "resources change",
+			mutate: func(web *epamcomv1beta1.Web) {
+				web.Spec.Resources = corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				}
+			},
+			wantDep: func(dep *appsv1.Deployment) bool {
+				qty := dep.Spec.Template.Spec.Containers[0].Resources.Requests[corev1.ResourceCPU]
+				return qty.String() == "100m"
+			},
+		},
+		{
+			name: "probes change",
+			mutate: func(web *epamcomv1beta1.Web) {
+				web.Spec.Probes = &epamcomv1beta1.ProbesSpec{LivenessPath: "/healthz", ReadinessPath: "/readyz"}
+			},
+			wantDep: func(dep *appsv1.Deployment) bool {
+				container := dep.Spec.Template.Spec.Containers[0]
+				return container.LivenessProbe != nil && container.LivenessProbe.HTTPGet.Path == "/healthz" &&
+					container.ReadinessProbe != nil && container.ReadinessProbe.HTTPGet.Path == "/readyz"
+			},
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			web := &epamcomv1beta1.Web{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("drift-%d", i),
+					Namespace: "default",
+				},
+				Spec: epamcomv1beta1.WebSpec{
+					Replicas: 1,
+					Port:     8080,
+					Image:    "nginx:1.25",
+					Content:  epamcomv1beta1.ContentSpec{Inline: "<html>hello</html>"},
+				},
+			}
+			if err := k8sClient.Create(testCtx, web); err != nil {
+				t.Fatalf("create Web: %v", err)
+			}
+			name := types.NamespacedName{Name: web.Name, Namespace: web.Namespace}
+			reconcileUntilStable(t, r, name)
+
+			if err := k8sClient.Get(testCtx, name, web); err != nil {
+				t.Fatalf("get Web: %v", err)
+			}
+			tt.mutate(web)
+			if err := k8sClient.Update(testCtx, web); err != nil {
+				t.Fatalf("update Web: %v", err)
+			}
+			reconcileUntilStable(t, r, name)
+
+			if tt.wantDep != nil {
+				var dep appsv1.Deployment
+				if err := k8sClient.Get(testCtx, types.NamespacedName{Name: web.Name + "deployment", Namespace: web.Namespace}, &dep); err != nil {
+					t.Fatalf("get Deployment: %v", err)
+				}
+				if !tt.wantDep(&dep) {
+					t.Errorf("deployment did not reflect drift: %+v", dep.Spec)
+				}
+			}
+			if tt.wantCM != nil {
+				var cm corev1.ConfigMap
+				if err := k8sClient.Get(testCtx, types.NamespacedName{Name: web.Name + "-cm", Namespace: web.Namespace}, &cm); err != nil {
+					t.Fatalf("get ConfigMap: %v", err)
+				}
+				if !tt.wantCM(&cm) {
+					t.Errorf("configmap did not reflect drift: %+v", cm.Data)
+				}
+			}
+
+			if err := k8sClient.Get(testCtx, name, web); err != nil {
+				t.Fatalf("get Web: %v", err)
+			}
+			if web.Status.ObservedGeneration != web.Generation {
+				t.Errorf("ObservedGeneration = %d, want %d", web.Status.ObservedGeneration, web.Generation)
+			}
+			cond := func() *metav1.Condition {
+				for i := range web.Status.Conditions {
+					if web.Status.Conditions[i].Type == typeAvailableWeb {
+						return &web.Status.Conditions[i]
+					}
+				}
+				return nil
+			}()
+			if cond == nil || cond.Status != metav1.ConditionTrue {
+				t.Errorf("Available condition = %+v, want True", cond)
+			}
+
+			_ = client.IgnoreNotFound(k8sClient.Delete(testCtx, web))
+		})
+	}
+}
+
+func TestReconcileCreatesService(t *testing.T) {
+	requireEnvtest(t)
+
+	r := &WebReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+
+	web := &epamcomv1beta1.Web{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "svc-web",
+			Namespace: "default",
+		},
+		Spec: epamcomv1beta1.WebSpec{
+			Replicas: 1,
+			Port:     8080,
+			Image:    "nginx:1.25",
+			Content:  epamcomv1beta1.ContentSpec{Inline: "<html>hello</html>"},
+		},
+	}
+	if err := k8sClient.Create(testCtx, web); err != nil {
+		t.Fatalf("create Web: %v", err)
+	}
+	defer func() { _ = client.IgnoreNotFound(k8sClient.Delete(testCtx, web)) }()
+
+	name := types.NamespacedName{Name: web.Name, Namespace: web.Namespace}
+	reconcileUntilStable(t, r, name)
+
+	var svc corev1.Service
+	if err := k8sClient.Get(testCtx, types.NamespacedName{Name: web.Name + "-svc", Namespace: web.Namespace}, &svc); err != nil {
+		t.Fatalf("get Service: %v", err)
+	}
+	if len(svc.Spec.Ports) != 1 || svc.Spec.Ports[0].Port != web.Spec.Port {
+		t.Errorf("Service ports = %+v, want single port %d", svc.Spec.Ports, web.Spec.Port)
+	}
+	if svc.Spec.Selector["app"] != web.Name {
+		t.Errorf("Service selector = %+v, want app=%s", svc.Spec.Selector, web.Name)
+	}
+
+	if err := k8sClient.Get(testCtx, name, web); err != nil {
+		t.Fatalf("get Web: %v", err)
+	}
+	if len(web.Status.URLs) == 0 {
+		t.Errorf("Web.Status.URLs is empty, want at least the cluster-local Service URL")
+	}
+}
+
+func TestReconcileFinalizerDrainsDeployment(t *testing.T) {
+	requireEnvtest(t)
+
+	recorder := record.NewFakeRecorder(10)
+	r := &WebReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), Recorder: recorder}
+
+	web := &epamcomv1beta1.Web{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "finalize-web",
+			Namespace: "default",
+		},
+		Spec: epamcomv1beta1.WebSpec{
+			Replicas: 2,
+			Port:     8080,
+			Image:    "nginx:1.25",
+			Content:  epamcomv1beta1.ContentSpec{Inline: "<html>hello</html>"},
+		},
+	}
+	if err := k8sClient.Create(testCtx, web); err != nil {
+		t.Fatalf("create Web: %v", err)
+	}
+	name := types.NamespacedName{Name: web.Name, Namespace: web.Namespace}
+	reconcileUntilStable(t, r, name)
+
+	if err := k8sClient.Get(testCtx, name, web); err != nil {
+		t.Fatalf("get Web: %v", err)
+	}
+	found := false
+	for _, f := range web.Finalizers {
+		if f == webFinalizer {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("Web.Finalizers = %v, want %q present", web.Finalizers, webFinalizer)
+	}
+
+	if err := k8sClient.Delete(testCtx, web); err != nil {
+		t.Fatalf("delete Web: %v", err)
+	}
+
+	var dep appsv1.Deployment
+	for i := 0; i < 5; i++ {
+		if err := k8sClient.Get(testCtx, types.NamespacedName{Name: web.Name + "deployment", Namespace: web.Namespace}, &dep); err == nil {
+			dep.Status.ReadyReplicas = 0
+			_ = k8sClient.Status().Update(testCtx, &dep)
+		}
+		if _, err := r.Reconcile(testCtx, ctrl.Request{NamespacedName: name}); err != nil {
+			t.Fatalf("reconcile %d: %v", i, err)
+		}
+		if err := k8sClient.Get(testCtx, name, web); client.IgnoreNotFound(err) != nil {
+			t.Fatalf("get Web: %v", err)
+		} else if err != nil {
+			// Web is gone: finalization completed.
+			return
+		}
+	}
+	t.Fatalf("Web %s was not finalized", name)
+}